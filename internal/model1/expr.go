@@ -0,0 +1,294 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of K9s
+
+package model1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ColumnLookup resolves a column name to its numeric value for the current
+// row. It reports false when the column is missing from the row or its
+// value isn't numeric.
+type ColumnLookup func(name string) (float64, bool)
+
+// ExprNode is a node in a parsed computed-column expression.
+type ExprNode interface {
+	// Eval evaluates the node for the current row, resolving column
+	// references through lookup. It reports false if any referenced
+	// value is missing or non-numeric, so callers can fall back to "-".
+	Eval(lookup ColumnLookup) (float64, bool)
+}
+
+type numNode struct{ val float64 }
+
+func (n numNode) Eval(ColumnLookup) (float64, bool) {
+	return n.val, true
+}
+
+type colNode struct{ name string }
+
+func (n colNode) Eval(lookup ColumnLookup) (float64, bool) {
+	return lookup(n.name)
+}
+
+type negNode struct{ x ExprNode }
+
+func (n negNode) Eval(lookup ColumnLookup) (float64, bool) {
+	v, ok := n.x.Eval(lookup)
+	if !ok {
+		return 0, false
+	}
+	return -v, true
+}
+
+type binNode struct {
+	op   byte
+	l, r ExprNode
+}
+
+func (n binNode) Eval(lookup ColumnLookup) (float64, bool) {
+	l, ok := n.l.Eval(lookup)
+	if !ok {
+		return 0, false
+	}
+	r, ok := n.r.Eval(lookup)
+	if !ok {
+		return 0, false
+	}
+
+	switch n.op {
+	case '+':
+		return l + r, true
+	case '-':
+		return l - r, true
+	case '*':
+		return l * r, true
+	case '/':
+		if r == 0 {
+			return 0, false
+		}
+		return l / r, true
+	case '%':
+		// Operands are truncated to integers, so a divisor in (-1, 1)
+		// becomes zero too.
+		if int64(r) == 0 {
+			return 0, false
+		}
+		return float64(int64(l) % int64(r)), true
+	default:
+		return 0, false
+	}
+}
+
+// ParseExpr compiles a computed-column expression, eg "CPU / CPU_LIM * 100"
+// or "[%CPU/L] + [%MEM/L]", into an evaluable ExprNode tree. It supports the
+// operators + - * / %, parenthesized grouping, references to other column
+// names (bracketed when the name itself contains '/' or '%', eg a real
+// k9s limit column), and numeric literals carrying a Kubernetes
+// resource-quantity unit suffix (Mi, Gi, m...).
+func ParseExpr(expr string) (ExprNode, error) {
+	p := &exprParser{toks: tokenize(expr)}
+
+	node, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.toks) {
+		return nil, fmt.Errorf("unexpected token %q in expression %q", p.toks[p.pos].text, expr)
+	}
+
+	return node, nil
+}
+
+type tokKind int
+
+const (
+	tokNum tokKind = iota
+	tokIdent
+	tokOp
+	tokLParen
+	tokRParen
+)
+
+type token struct {
+	kind tokKind
+	text string
+}
+
+func tokenize(expr string) []token {
+	var toks []token
+
+	rr := []rune(expr)
+	for i := 0; i < len(rr); {
+		switch {
+		case unicode.IsSpace(rr[i]):
+			i++
+		case rr[i] == '(':
+			toks, i = append(toks, token{tokLParen, "("}), i+1
+		case rr[i] == ')':
+			toks, i = append(toks, token{tokRParen, ")"}), i+1
+		case rr[i] == '[':
+			// Bracketed column reference, eg [%CPU/L], so names containing
+			// '/' or '%' (real k9s metric/limit columns) aren't mistaken
+			// for operators.
+			j := i + 1
+			for j < len(rr) && rr[j] != ']' {
+				j++
+			}
+			name := string(rr[i+1 : j])
+			if j < len(rr) {
+				j++
+			}
+			toks, i = append(toks, token{tokIdent, name}), j
+		case strings.ContainsRune("+-*/%", rr[i]):
+			toks, i = append(toks, token{tokOp, string(rr[i])}), i+1
+		case unicode.IsDigit(rr[i]) || rr[i] == '.':
+			j := i
+			for j < len(rr) && (unicode.IsDigit(rr[j]) || rr[j] == '.') {
+				j++
+			}
+			// Optional unit suffix glued to the literal, eg 100Mi, 500m.
+			for j < len(rr) && unicode.IsLetter(rr[j]) {
+				j++
+			}
+			toks, i = append(toks, token{tokNum, string(rr[i:j])}), j
+		case unicode.IsLetter(rr[i]) || rr[i] == '_':
+			j := i
+			for j < len(rr) && (unicode.IsLetter(rr[j]) || unicode.IsDigit(rr[j]) || rr[j] == '_') {
+				j++
+			}
+			toks, i = append(toks, token{tokIdent, string(rr[i:j])}), j
+		default:
+			i++
+		}
+	}
+
+	return toks
+}
+
+// exprParser is a small recursive-descent parser over the grammar:
+//
+//	expr   := term (('+' | '-') term)*
+//	term   := factor (('*' | '/' | '%') factor)*
+//	factor := '-' factor | NUMBER | IDENT | '(' expr ')'
+type exprParser struct {
+	toks []token
+	pos  int
+}
+
+func (p *exprParser) peek() (token, bool) {
+	if p.pos >= len(p.toks) {
+		return token{}, false
+	}
+	return p.toks[p.pos], true
+}
+
+func (p *exprParser) parseExpr() (ExprNode, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		t, ok := p.peek()
+		if !ok || t.kind != tokOp || (t.text != "+" && t.text != "-") {
+			break
+		}
+		p.pos++
+
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		left = binNode{op: t.text[0], l: left, r: right}
+	}
+
+	return left, nil
+}
+
+func (p *exprParser) parseTerm() (ExprNode, error) {
+	left, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		t, ok := p.peek()
+		if !ok || t.kind != tokOp || (t.text != "*" && t.text != "/" && t.text != "%") {
+			break
+		}
+		p.pos++
+
+		right, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		left = binNode{op: t.text[0], l: left, r: right}
+	}
+
+	return left, nil
+}
+
+func (p *exprParser) parseFactor() (ExprNode, error) {
+	t, ok := p.peek()
+	if !ok {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+
+	if t.kind == tokOp && t.text == "-" {
+		p.pos++
+		x, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		return negNode{x}, nil
+	}
+
+	switch t.kind {
+	case tokLParen:
+		p.pos++
+		node, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		closing, ok := p.peek()
+		if !ok || closing.kind != tokRParen {
+			return nil, fmt.Errorf("missing closing parenthesis in expression")
+		}
+		p.pos++
+		return node, nil
+	case tokNum:
+		p.pos++
+		v, err := parseQuantity(t.text)
+		if err != nil {
+			return nil, err
+		}
+		return numNode{v}, nil
+	case tokIdent:
+		p.pos++
+		return colNode{t.text}, nil
+	default:
+		return nil, fmt.Errorf("unexpected token %q", t.text)
+	}
+}
+
+// parseQuantity converts a numeric literal, optionally suffixed with a
+// Kubernetes resource-quantity unit (Mi, Gi, m...), into a float64.
+func parseQuantity(s string) (float64, error) {
+	if v, err := strconv.ParseFloat(s, 64); err == nil {
+		return v, nil
+	}
+
+	q, err := resource.ParseQuantity(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid numeric literal %q: %w", s, err)
+	}
+
+	return q.AsApproximateFloat64(), nil
+}
@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of K9s
+
+package model1_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/model1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseExprBracketedColumnReference(t *testing.T) {
+	node, err := model1.ParseExpr("[%CPU/L] + [%MEM/L]")
+	assert.NoError(t, err)
+
+	lookup := func(name string) (float64, bool) {
+		switch name {
+		case "%CPU/L":
+			return 40, true
+		case "%MEM/L":
+			return 25, true
+		default:
+			return 0, false
+		}
+	}
+
+	v, ok := node.Eval(lookup)
+	assert.True(t, ok)
+	assert.Equal(t, float64(65), v)
+}
+
+func TestParseExprEval(t *testing.T) {
+	lookup := func(values map[string]float64) model1.ColumnLookup {
+		return func(name string) (float64, bool) {
+			v, ok := values[name]
+			return v, ok
+		}
+	}
+
+	uu := map[string]struct {
+		expr   string
+		values map[string]float64
+		want   float64
+		ok     bool
+	}{
+		"precedence-mul-before-add": {
+			expr:   "CPU + CPU_LIM * 2",
+			values: map[string]float64{"CPU": 10, "CPU_LIM": 5},
+			want:   20,
+			ok:     true,
+		},
+		"precedence-div-before-sub": {
+			expr:   "MEM - MEM_LIM / 4",
+			values: map[string]float64{"MEM": 100, "MEM_LIM": 40},
+			want:   90,
+			ok:     true,
+		},
+		"parenthesization-overrides-precedence": {
+			expr:   "(CPU + CPU_LIM) * 2",
+			values: map[string]float64{"CPU": 10, "CPU_LIM": 5},
+			want:   30,
+			ok:     true,
+		},
+		"nested-parens": {
+			expr:   "((CPU + 1) * (CPU_LIM - 1))",
+			values: map[string]float64{"CPU": 9, "CPU_LIM": 3},
+			want:   20,
+			ok:     true,
+		},
+		"division-by-zero-is-not-ok": {
+			expr:   "CPU / CPU_LIM",
+			values: map[string]float64{"CPU": 10, "CPU_LIM": 0},
+			ok:     false,
+		},
+		"modulo-by-zero-is-not-ok": {
+			expr:   "CPU % CPU_LIM",
+			values: map[string]float64{"CPU": 10, "CPU_LIM": 0},
+			ok:     false,
+		},
+		"modulo": {
+			expr:   "CPU % CPU_LIM",
+			values: map[string]float64{"CPU": 10, "CPU_LIM": 3},
+			want:   1,
+			ok:     true,
+		},
+		"missing-column-is-not-ok": {
+			expr:   "CPU / CPU_LIM",
+			values: map[string]float64{"CPU": 10},
+			ok:     false,
+		},
+		"unary-minus": {
+			expr:   "-CPU + CPU_LIM",
+			values: map[string]float64{"CPU": 10, "CPU_LIM": 5},
+			want:   -5,
+			ok:     true,
+		},
+		"unit-suffix-mi": {
+			expr:   "MEM / 128Mi * 100",
+			values: map[string]float64{"MEM": float64(64 * 1024 * 1024)},
+			want:   50,
+			ok:     true,
+		},
+		"unit-suffix-milli": {
+			expr:   "CPU / 500m",
+			values: map[string]float64{"CPU": 0.25},
+			want:   0.5,
+			ok:     true,
+		},
+	}
+
+	for k := range uu {
+		u := uu[k]
+		t.Run(k, func(t *testing.T) {
+			node, err := model1.ParseExpr(u.expr)
+			assert.NoError(t, err)
+
+			v, ok := node.Eval(lookup(u.values))
+			assert.Equal(t, u.ok, ok)
+			if u.ok {
+				assert.InDelta(t, u.want, v, 0.0001)
+			}
+		})
+	}
+}
@@ -7,18 +7,74 @@ import (
 	"reflect"
 	"regexp"
 	"strings"
+	"sync"
 
 	"github.com/rs/zerolog/log"
+	"k8s.io/client-go/util/jsonpath"
 )
 
 const ageCol = "AGE"
 
+// ExtractionKind identifies where an ExtractionInfo sources its value from.
+type ExtractionKind int
+
+// Supported extraction kinds.
+const (
+	ExtractLabel ExtractionKind = iota
+	ExtractAnnotation
+	ExtractJSONPath
+	ExtractField
+	ExtractComputed
+)
+
 // ExtractionInfo stores data for a field to extract value from another field
 type ExtractionInfo struct {
 	IdxInFields int
 	CustomName  string
 	HeaderName  string
 	Key         string
+	Kind        ExtractionKind
+	Path        *jsonpath.JSONPath
+	Expr        ExprNode
+}
+
+// computedColRX matches a computed-column definition, eg:
+// "USAGE%:= CPU / CPU_LIM * 100".
+var computedColRX = regexp.MustCompile(`^([^:=]+):=\s*(.+)$`)
+
+// splitComputedColumn reports whether col is a computed-column definition
+// and, if so, returns its display name and the expression to evaluate.
+func splitComputedColumn(col string) (string, string, bool) {
+	matches := computedColRX.FindStringSubmatch(col)
+	if matches == nil {
+		return "", "", false
+	}
+
+	return strings.TrimSpace(matches[1]), strings.TrimSpace(matches[2]), true
+}
+
+// classifyExtraction figures out what a custom column's bracketed reference
+// points at and, for JSONPath references, compiles it up front so MapIndices
+// only pays the parse cost once.
+func classifyExtraction(headerName, key string) (ExtractionKind, *jsonpath.JSONPath) {
+	switch headerName {
+	case "LABELS":
+		return ExtractLabel, nil
+	case "ANNOTATIONS":
+		return ExtractAnnotation, nil
+	}
+
+	if !strings.HasPrefix(key, "$.") && !strings.HasPrefix(key, "$[") {
+		return ExtractField, nil
+	}
+
+	jp := jsonpath.New(headerName)
+	if err := jp.Parse("{" + key + "}"); err != nil {
+		log.Error().Err(err).Msgf("Unable to compile JSONPath %q for column %q", key, headerName)
+		return ExtractField, nil
+	}
+
+	return ExtractJSONPath, jp
 }
 
 // ExtractionInfoBag store ExtractionInfo by using the index of the column
@@ -34,6 +90,7 @@ type HeaderColumn struct {
 	Time      bool
 	Capacity  bool
 	VS        bool
+	Pinned    bool
 }
 
 // Clone copies a header.
@@ -76,21 +133,71 @@ func (h Header) Labelize(cols []int, labelCol int, rr *RowEvents) Header {
 	return header
 }
 
+// WideFallbackPolicy controls what happens, in wide mode, to base header
+// columns a ColumnProfile doesn't mention explicitly.
+type WideFallbackPolicy int
+
+const (
+	// WideFallbackAppend tacks unmentioned base columns on as wide columns.
+	WideFallbackAppend WideFallbackPolicy = iota
+	// WideFallbackHide drops unmentioned base columns entirely.
+	WideFallbackHide
+)
+
+// ColumnProfile is a named, ordered view over a Header: which columns are
+// visible, in what order, how many are pinned (frozen) at the front, and how
+// columns absent from the list behave in wide mode. Users declare profiles
+// in views.yaml and switch between them at runtime.
+type ColumnProfile struct {
+	Name         string
+	Columns      []string
+	PinnedCount  int
+	WideFallback WideFallbackPolicy
+}
+
+// NewColumnProfile builds an unnamed, unpinned ColumnProfile from the legacy
+// columns+wide pair, preserving the historical wide-append behavior.
+func NewColumnProfile(cols []string, wide bool) ColumnProfile {
+	policy := WideFallbackHide
+	if wide {
+		policy = WideFallbackAppend
+	}
+
+	return ColumnProfile{Columns: cols, WideFallback: policy}
+}
+
 func (h Header) MapIndices(cols []string, wide bool) ([]int, ExtractionInfoBag) {
 	var (
 		ii   = make([]int, 0, len(cols))
 		eib  = make(ExtractionInfoBag)
-		rgx  = regexp.MustCompile(`^(?:([^:]+):\s*)?(.*)\[(.*)\]$`)
+		rgx  = regexp.MustCompile(`^(?:([^:]+):\s*)?([^[]+)\[(.*)\]$`)
 	)
 
 	for _, col := range cols {
-		idx, ok := h.IndexOf(col, true)
-		if !ok {
+		if name, expr, ok := splitComputedColumn(col); ok {
+			ii = append(ii, -1)
+			node, err := ParseExpr(expr)
+			if err != nil {
+				log.Error().Err(err).Msgf("Invalid computed column %q", col)
+				continue
+			}
+			log.Info().Msgf("Computed column %q will be displayed as %q", col, name)
+			eib[len(ii)-1] = ExtractionInfo{IdxInFields: -1, CustomName: name, Kind: ExtractComputed, Expr: node}
+			continue
+		}
+
+		if rgx.MatchString(col) {
+			ii = append(ii, -1)
+		} else if idx, kind, ok := h.Resolve(col); ok {
+			if kind != ResolveExact {
+				log.Warn().Msgf("Column %q resolved via %s match to %q", col, kind, h[idx].Name)
+			}
+			ii = append(ii, idx)
+		} else {
 			log.Warn().Msgf("Column %q not found on resource", col)
+			ii = append(ii, -1)
 		}
-		
-		ii = append(ii, idx)
-		
+
 		if !rgx.MatchString(col) {
 			continue
 		}
@@ -102,44 +209,67 @@ func (h Header) MapIndices(cols []string, wide bool) ([]int, ExtractionInfoBag)
 		}
 		
 		customName := strings.TrimSpace(matches[1]) // For example, GROUP
-		headerName := matches[2]                    // For example, LABELS
-		key := matches[3]                           // For example, platform.isolation/nodegroup
+		headerName := matches[2]                    // For example, LABELS, ANNOTATIONS, STATUS, SPEC
+		key := matches[3]                           // For example, platform.isolation/nodegroup or a JSONPath expr
 
-		if headerName != "LABELS" {
-			log.Warn().Msgf("Custom Column %q is not supported", col)
-			continue
-		}
+		kind, path := classifyExtraction(headerName, key)
 
 		log.Info().Msgf("Custom column %q will be displayed as %q", col, customName)
 
-		idxInFields, _ := h.IndexOf(headerName, true)
-		eib[len(ii)-1] = ExtractionInfo{idxInFields, customName, headerName, key}
+		// IdxInFields only means something for ExtractLabel, where it is the
+		// pre-flattened LABELS column. The other kinds read the raw object
+		// (via Path or Key), so leave it at -1 instead of storing the
+		// unrelated base-header index of ANNOTATIONS/STATUS/SPEC.
+		idxInFields := -1
+		if kind == ExtractLabel {
+			idxInFields, _ = h.IndexOf(headerName, true)
+		}
+		eib[len(ii)-1] = ExtractionInfo{
+			IdxInFields: idxInFields,
+			CustomName:  customName,
+			HeaderName:  headerName,
+			Key:         key,
+			Kind:        kind,
+			Path:        path,
+		}
 	}
 
 	return ii, eib
 }
 
-func (h Header) Customize(cols []string, wide bool) Header {
-	if len(cols) == 0 {
+// Customize rebuilds the header according to a ColumnProfile: the profile's
+// column list wins, in its order, pinning its leading PinnedCount columns,
+// and WideFallback decides what happens to columns the profile leaves out.
+func (h Header) Customize(p ColumnProfile) Header {
+	if len(p.Columns) == 0 {
 		return h
 	}
 
+	wide := p.WideFallback == WideFallbackAppend
+
 	cc := make(Header, 0, len(h))
 	xx := make(map[int]struct{}, len(h))
 
 	// Get column indices and custom name information
-	_, extractionInfoBag := h.MapIndices(cols, wide)
+	_, extractionInfoBag := h.MapIndices(p.Columns, wide)
 
-	for i, c := range cols {
-		idx, ok := h.IndexOf(c, true)
+	for i, c := range p.Columns {
+		idx, _, ok := h.Resolve(c)
 		if !ok {
-			cc = append(cc, HeaderColumn{Name: extractionInfoBag[i].CustomName})
+			info := extractionInfoBag[i]
+			col := HeaderColumn{Name: info.CustomName}
+			if info.Kind == ExtractComputed {
+				col.MX = true
+			}
+			col.Pinned = i < p.PinnedCount
+			cc = append(cc, col)
 			continue
 		}
 		xx[idx] = struct{}{}
 
 		col := h[idx].Clone()
 		col.Wide = false
+		col.Pinned = i < p.PinnedCount
 
 		cc = append(cc, col)
 	}
@@ -161,6 +291,39 @@ func (h Header) Customize(cols []string, wide bool) Header {
 	return cc
 }
 
+// Reorder returns a new Header with columns rearranged according to indices,
+// eg a column order persisted by a ColumnProfile. Out-of-range indices are
+// skipped.
+func (h Header) Reorder(indices []int) Header {
+	he := make(Header, 0, len(indices))
+	for _, i := range indices {
+		if i < 0 || i >= len(h) {
+			continue
+		}
+		he = append(he, h[i].Clone())
+	}
+
+	return he
+}
+
+// Hide returns a new Header with the named columns removed.
+func (h Header) Hide(names ...string) Header {
+	hide := make(map[string]struct{}, len(names))
+	for _, n := range names {
+		hide[n] = struct{}{}
+	}
+
+	he := make(Header, 0, len(h))
+	for _, c := range h {
+		if _, ok := hide[c.Name]; ok {
+			continue
+		}
+		he = append(he, c)
+	}
+
+	return he
+}
+
 
 // Diff returns true if the header changed.
 func (h Header) Diff(header Header) bool {
@@ -233,6 +396,159 @@ func (h Header) IndexOf(colName string, includeWide bool) (int, bool) {
 	return -1, false
 }
 
+// ResolveKind reports how Header.Resolve matched a column name.
+type ResolveKind int
+
+const (
+	// ResolveExact means the name matched a column verbatim.
+	ResolveExact ResolveKind = iota
+	// ResolveAlias means the name matched through the column alias map.
+	ResolveAlias
+	// ResolveCaseInsensitive means the name matched ignoring case.
+	ResolveCaseInsensitive
+	// ResolveFuzzy means the name matched the closest column within
+	// fuzzyMaxDistance edits.
+	ResolveFuzzy
+)
+
+// String returns a human-readable label for the resolve kind.
+func (k ResolveKind) String() string {
+	switch k {
+	case ResolveExact:
+		return "exact"
+	case ResolveAlias:
+		return "alias"
+	case ResolveCaseInsensitive:
+		return "case-insensitive"
+	case ResolveFuzzy:
+		return "fuzzy"
+	default:
+		return "unknown"
+	}
+}
+
+// fuzzyMaxDistance is the largest Levenshtein distance Resolve will accept
+// as a fuzzy match. fuzzyMinNameLen is the length a queried or candidate
+// name must exceed before fuzzy matching applies at all, and a match must also
+// have its distance under half the candidate's length — short column names
+// like NODE/NAME are only 1-2 edits apart yet mean unrelated things.
+const (
+	fuzzyMaxDistance = 2
+	fuzzyMinNameLen  = 5
+)
+
+// columnAliases maps a shorthand column name to its canonical upstream name,
+// eg "NS" -> "NAMESPACE". Keys are upper-cased for case-insensitive lookups.
+//
+// This map is process-wide, not per-resource: it is consulted by every
+// Header's Resolve, so an alias registered for one view (eg IP -> POD-IP)
+// is visible to headers that have no such column. columnAliasesMu guards it
+// since RegisterColumnAlias can run concurrently with the table refreshes
+// that call Resolve.
+var (
+	columnAliasesMu sync.RWMutex
+	columnAliases   = map[string]string{
+		"NS": "NAMESPACE",
+		"IP": "POD-IP",
+	}
+)
+
+// RegisterColumnAlias adds or overrides a shorthand alias resolved by
+// Header.Resolve, eg RegisterColumnAlias("PROJ", "NAMESPACE"). It is
+// process-wide, so callers sharing a process should pick aliases that make
+// sense across every resource type.
+func RegisterColumnAlias(alias, canonical string) {
+	columnAliasesMu.Lock()
+	defer columnAliasesMu.Unlock()
+	columnAliases[strings.ToUpper(alias)] = canonical
+}
+
+// resolveColumnAlias looks up alias in columnAliases under its read lock.
+func resolveColumnAlias(alias string) (string, bool) {
+	columnAliasesMu.RLock()
+	defer columnAliasesMu.RUnlock()
+	canonical, ok := columnAliases[strings.ToUpper(alias)]
+	return canonical, ok
+}
+
+// Resolve looks up colName against the header, trying progressively looser
+// matches: an exact match, the alias map, a case-insensitive match, and
+// finally a fuzzy match within fuzzyMaxDistance edits. It reports which kind
+// of match succeeded so callers can warn instead of silently failing.
+func (h Header) Resolve(colName string) (int, ResolveKind, bool) {
+	if idx, ok := h.IndexOf(colName, true); ok {
+		return idx, ResolveExact, true
+	}
+
+	if canonical, ok := resolveColumnAlias(colName); ok {
+		if idx, ok := h.IndexOf(canonical, true); ok {
+			return idx, ResolveAlias, true
+		}
+	}
+
+	for i, c := range h {
+		if strings.EqualFold(c.Name, colName) {
+			return i, ResolveCaseInsensitive, true
+		}
+	}
+
+	// Short names (eg "NODE" vs "NAME") are only 1-2 edits apart yet mean
+	// completely different columns, so fuzzy matching requires the name be
+	// meaningfully longer than the distance, not just within fuzzyMaxDistance.
+	if len(colName) <= fuzzyMinNameLen {
+		return -1, ResolveExact, false
+	}
+
+	best, bestDist := -1, fuzzyMaxDistance+1
+	for i, c := range h {
+		if len(c.Name) <= fuzzyMinNameLen {
+			continue
+		}
+		if d := levenshtein(strings.ToUpper(colName), strings.ToUpper(c.Name)); d < bestDist && d*2 < len(c.Name) {
+			best, bestDist = i, d
+		}
+	}
+	if best >= 0 && bestDist <= fuzzyMaxDistance {
+		return best, ResolveFuzzy, true
+	}
+
+	return -1, ResolveExact, false
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	prev := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ar); i++ {
+		cur := make([]int, len(br)+1)
+		cur[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			cur[j] = min3(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+
+	return prev[len(br)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
 // Dump for debugging.
 func (h Header) Dump() {
 	log.Debug().Msgf("HEADER")
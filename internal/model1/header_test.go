@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of K9s
+
+package model1_test
+
+import (
+	"testing"
+
+	"github.com/derailed/k9s/internal/model1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapIndicesJSONPathBracketedKey(t *testing.T) {
+	h := model1.Header{
+		{Name: "NAME"},
+		{Name: "STATUS"},
+		{Name: "SPEC"},
+	}
+
+	_, eib := h.MapIndices([]string{
+		`READY:STATUS[$.conditions[?(@.type=="Ready")].status]`,
+		`IMAGE:SPEC[$.containers[0].image]`,
+	}, false)
+
+	ready := eib[0]
+	assert.Equal(t, "READY", ready.CustomName)
+	assert.Equal(t, model1.ExtractJSONPath, ready.Kind)
+	assert.Equal(t, `$.conditions[?(@.type=="Ready")].status`, ready.Key)
+	assert.NotNil(t, ready.Path)
+
+	image := eib[1]
+	assert.Equal(t, "IMAGE", image.CustomName)
+	assert.Equal(t, model1.ExtractJSONPath, image.Kind)
+	assert.Equal(t, `$.containers[0].image`, image.Key)
+	assert.NotNil(t, image.Path)
+}
+
+func TestCustomizeResolvesLooseColumnMatches(t *testing.T) {
+	h := model1.Header{
+		{Name: "NAMESPACE"},
+		{Name: "NAME"},
+		{Name: "AGE", Time: true},
+	}
+
+	cc := h.Customize(model1.NewColumnProfile([]string{"ns", "age"}, false))
+
+	assert.Equal(t, "NAMESPACE", cc[0].Name)
+	assert.Equal(t, "AGE", cc[1].Name)
+	assert.True(t, cc[1].Time)
+}